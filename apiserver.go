@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/raffalskaya/the-final-task/internal/api"
+)
+
+// apiServer implements api.StrictServerInterface (generated from api/openapi.yaml via
+// oapi-codegen's strict-server mode) against the job-backed calculator core, so request
+// body decoding and the error envelope come from the generated code rather than
+// hand-rolled json.Unmarshal/json.Marshal pairs
+type apiServer struct {
+	pool *WorkerPool
+}
+
+// Calculate enqueues an expression for asynchronous evaluation (POST /api/v1/calculate)
+func (s *apiServer) Calculate(ctx context.Context, request api.CalculateRequestObject) (api.CalculateResponseObject, error) {
+	job := s.pool.Store().Create(request.Body.Expression)
+	if !s.pool.Enqueue(job) {
+		s.pool.Store().Delete(job.ID)
+		return api.Calculate503JSONResponse{Error: "calculation queue is full, try again later"}, nil
+	}
+
+	return api.Calculate201JSONResponse{Id: job.ID}, nil
+}
+
+// requireJSONContentType rejects requests to the generated API routes whose body isn't
+// declared as application/json, before the generated strict handler ever tries to decode it
+func requireJSONContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", requestIDFromContext(r.Context()))
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			writeAPIError(w, http.StatusBadRequest, "Content-Type must be application/json")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	errorBytes, err := json.Marshal(&api.BadAnswer{Error: message})
+	if err != nil {
+		http.Error(w, ErrExpressionSyntax.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(errorBytes)
+}
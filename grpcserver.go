@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/raffalskaya/the-final-task/pb"
+)
+
+// grpcAddr - отдельный порт, на котором поднимается gRPC-зеркало HTTP API
+const grpcAddr = ":9090"
+
+// calculatorServer реализует pb.CalculatorServer поверх того же ядра Calc, что и
+// apiServer.Calculate, чтобы не дублировать логику вычисления выражений
+type calculatorServer struct {
+	pb.UnimplementedCalculatorServer
+}
+
+func (s *calculatorServer) Evaluate(ctx context.Context, req *pb.ExpressionRequest) (*pb.ExpressionResponse, error) {
+	result, err := Calc(req.GetExpression())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.ExpressionResponse{Result: result}, nil
+}
+
+func (s *calculatorServer) EvaluateBatch(stream pb.Calculator_EvaluateBatchServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &pb.ExpressionResponse{}
+		result, err := Calc(req.GetExpression())
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Result = result
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcError сопоставляет ошибки калькулятора с кодами статуса gRPC
+func grpcError(err error) error {
+	switch err {
+	case ErrDivisionByZero:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrExpressionSyntax, ErrInvalidExpression:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// serveGRPC запускает gRPC-сервер калькулятора и блокируется, пока он не остановится
+func serveGRPC(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("grpc listen: %w", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterCalculatorServer(grpcServer, &calculatorServer{})
+	return grpcServer.Serve(lis)
+}
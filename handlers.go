@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/raffalskaya/the-final-task/internal/api"
+)
+
+// NewRouter собирает маршруты асинхронного REST API калькулятора и оборачивает их в
+// middleware, которая логирует запросы и записывает метрики Prometheus. /api/v1/calculate
+// приходит из сгенерированного по api/openapi.yaml StrictServerInterface, остальные
+// маршруты пока остаются на ручных обработчиках.
+func NewRouter(pool *WorkerPool) http.Handler {
+	router := mux.NewRouter()
+	strictHandler := api.NewStrictHandlerWithOptions(&apiServer{pool: pool}, nil, api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+		},
+	})
+	api.HandlerWithOptions(strictHandler, api.GorillaServerOptions{
+		BaseRouter:  router,
+		Middlewares: []api.MiddlewareFunc{requireJSONContentType},
+	})
+	router.HandleFunc("/api/v1/expressions", ListExpressionsHandler(pool.Store()))
+	router.HandleFunc("/api/v1/expressions/{id}", GetExpressionHandler(pool.Store()))
+	router.HandleFunc("/healthz", HealthzHandler)
+	router.Handle("/metrics", promhttp.Handler())
+	return loggingMiddleware(router)
+}
+
+// HealthzHandler сообщает, что процесс жив и готов принимать запросы
+func HealthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprint(w, "ok")
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	errorBytes, err := json.Marshal(&BadAnswer{Error: message})
+	if err != nil {
+		http.Error(w, ErrExpressionSyntax.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Error(w, string(errorBytes), status)
+}
+
+// ListExpressionsHandler возвращает все заведённые задания с их текущим статусом
+func ListExpressionsHandler(store JobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, ErrNotPostMethod.Error(), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]*Job{"expressions": store.All()})
+	}
+}
+
+// GetExpressionHandler возвращает одно задание по идентификатору из пути запроса
+func GetExpressionHandler(store JobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, ErrNotPostMethod.Error(), http.StatusMethodNotAllowed)
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/expressions/")
+		if id == "" {
+			http.NotFound(w, r)
+			return
+		}
+		job, ok := store.Get(id)
+		if !ok {
+			writeJSONError(w, http.StatusNotFound, "expression not found")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(job)
+	}
+}
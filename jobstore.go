@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+// JobStatus описывает текущее состояние асинхронного задания на вычисление выражения
+type JobStatus string
+
+const (
+	StatusPending    JobStatus = "pending"
+	StatusInProgress JobStatus = "in_progress"
+	StatusDone       JobStatus = "done"
+	StatusError      JobStatus = "error"
+)
+
+// Job - одно асинхронное задание на вычисление выражения
+type Job struct {
+	ID         string    `json:"id"`
+	Expression string    `json:"-"`
+	Status     JobStatus `json:"status"`
+	Result     float64   `json:"result,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// JobStore хранит задания и позволяет их создавать, читать и обновлять
+type JobStore interface {
+	// Create заводит новое задание на вычисление expression в статусе StatusPending
+	Create(expression string) *Job
+	// Get возвращает задание по идентификатору
+	Get(id string) (*Job, bool)
+	// All возвращает все задания
+	All() []*Job
+	// Update атомарно применяет update к заданию id, если оно существует
+	Update(id string, update func(*Job))
+	// Delete удаляет задание, например если его не удалось поставить в очередь на вычисление
+	Delete(id string)
+}
+
+// MemoryJobStore - реализация JobStore поверх map, защищённой sync.RWMutex
+type MemoryJobStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewMemoryJobStore создаёт пустое хранилище заданий в памяти
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]*Job)}
+}
+
+func (s *MemoryJobStore) Create(expression string) *Job {
+	job := &Job{
+		ID:         newJobID(),
+		Expression: expression,
+		Status:     StatusPending,
+	}
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+	return job
+}
+
+func (s *MemoryJobStore) Get(id string) (*Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	jobCopy := *job
+	return &jobCopy, true
+}
+
+func (s *MemoryJobStore) All() []*Job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobCopy := *job
+		result = append(result, &jobCopy)
+	}
+	return result
+}
+
+func (s *MemoryJobStore) Update(id string, update func(*Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[id]; ok {
+		update(job)
+	}
+}
+
+func (s *MemoryJobStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+}
+
+// newJobID генерирует идентификатор задания в формате UUID v4
+func newJobID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err) // crypto/rand не должен возвращать ошибку в обычной среде
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
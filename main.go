@@ -1,13 +1,16 @@
 package main
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"log/slog"
+	"math"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 var (
@@ -33,12 +36,34 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// Операции и их приоритеты
+// Операции, их приоритеты и признак правоассоциативности
 var operations = map[string]int{
-	"+": 1,
-	"-": 1,
-	"*": 2,
-	"/": 2,
+	"u-": 4,
+	"u+": 4,
+	"^":  3,
+	"*":  2,
+	"/":  2,
+	"%":  2,
+	"+":  1,
+	"-":  1,
+}
+
+// Операторы, которые группируются справа налево (унарные и возведение в степень)
+var rightAssociative = map[string]bool{
+	"u-": true,
+	"u+": true,
+	"^":  true,
+}
+
+// Арность функций: сколько аргументов снять со стека при вычислении.
+// Отрицательное значение означает "переменное число аргументов, минимум -n".
+var functionArity = map[string]int{
+	"sqrt": 1,
+	"sin":  1,
+	"cos":  1,
+	"abs":  1,
+	"pow":  2,
+	"min":  -2,
 }
 
 // Функция, которая принимает на вход строку и проверяет, является ли эта строка оператором
@@ -49,78 +74,300 @@ func isOperator(s string) bool {
 
 // Функция, которая принимает оператор в виде строки и возвращает его приоритет
 func precedence(op string) int {
-	prio, _ := operations[op]
+	prio := operations[op]
 	return prio
 }
 
-// ConvertToPostfix преобразует выражение из инфиксной формы в постфиксную
-func convertToPostfix(infix []string) ([]string, error) {
-	var output []string
-	stack := make([]string, 0)
-	for _, token := range infix {
-		if token == "(" {
-			stack = append(stack, token)
-		} else if token == ")" {
-			for len(stack) > 0 && stack[len(stack)-1] != "(" {
-				output = append(output, stack[len(stack)-1])
-				stack = stack[:len(stack)-1]
+// isFunction проверяет, что токен - это имя известной функции
+func isFunction(s string) bool {
+	_, ok := functionArity[s]
+	return ok
+}
+
+// tokenKind различает виды токенов, которые участвуют в разборе выражения
+type tokenKind int
+
+const (
+	tokNumber tokenKind = iota
+	tokOperator
+	tokFunction
+	tokLeftParen
+	tokRightParen
+	tokComma
+)
+
+// token - один токен выражения; argc заполняется для tokFunction при переводе в постфикс
+type token struct {
+	kind tokenKind
+	val  string
+	argc int
+}
+
+// isDigit проверяет, что байт - это десятичная цифра
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// tokenize разбивает выражение на токены: многозначные числа, десятичные дроби и
+// числа в экспоненциальной записи (1.5e-3), операторы (+ - * / ^ %), скобки,
+// запятая-разделитель аргументов и имена функций (sqrt, sin, cos, abs, pow, min)
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []byte(strings.ReplaceAll(expression, " ", ""))
+	i := 0
+	prevKind := -1 // начало выражения: ещё не было токенов
+	for i < len(runes) {
+		c := runes[i]
+		switch {
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(runes) && isDigit(runes[i]) {
+				i++
+			}
+			if i < len(runes) && runes[i] == '.' {
+				i++
+				for i < len(runes) && isDigit(runes[i]) {
+					i++
+				}
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && isDigit(runes[j]) {
+					i = j
+					for i < len(runes) && isDigit(runes[i]) {
+						i++
+					}
+				}
 			}
-			if len(stack) == 0 || stack[len(stack)-1] != "(" {
+			tokens = append(tokens, token{kind: tokNumber, val: string(runes[start:i])})
+			prevKind = int(tokNumber)
+		case unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(runes) && unicode.IsLetter(rune(runes[i])) {
+				i++
+			}
+			name := string(runes[start:i])
+			if !isFunction(name) {
+				return nil, ErrExpressionSyntax
+			}
+			if i >= len(runes) || runes[i] != '(' {
+				// имя функции без последующего '(' - например "sqrt2" или "sin" в конце строки
+				return nil, ErrExpressionSyntax
+			}
+			tokens = append(tokens, token{kind: tokFunction, val: name})
+			prevKind = int(tokFunction)
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLeftParen, val: "("})
+			prevKind = int(tokLeftParen)
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRightParen, val: ")"})
+			prevKind = int(tokRightParen)
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokComma, val: ","})
+			prevKind = int(tokComma)
+			i++
+		case c == '+' || c == '-':
+			// унарный, если это начало выражения, или перед ним оператор, '(' или ','
+			isUnary := prevKind == -1 || prevKind == int(tokOperator) || prevKind == int(tokLeftParen) || prevKind == int(tokComma)
+			op := string(c)
+			if isUnary {
+				op = "u" + op
+			}
+			tokens = append(tokens, token{kind: tokOperator, val: op})
+			prevKind = int(tokOperator)
+			i++
+		case c == '*' || c == '/' || c == '^' || c == '%':
+			tokens = append(tokens, token{kind: tokOperator, val: string(c)})
+			prevKind = int(tokOperator)
+			i++
+		default:
+			return nil, ErrExpressionSyntax
+		}
+	}
+	return tokens, nil
+}
+
+// ConvertToPostfix преобразует выражение из инфиксной формы в постфиксную (алгоритм
+// сортировочной станции) с поддержкой унарных операторов, правоассоциативного '^'
+// и вызовов функций с переменным числом аргументов
+func convertToPostfix(infix []token) ([]token, error) {
+	var output []token
+	stack := make([]token, 0)
+	argCounts := make([]int, 0) // число аргументов для ближайшего вызова функции
+
+	popOperator := func() {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		output = append(output, top)
+	}
+
+	for _, tok := range infix {
+		switch tok.kind {
+		case tokNumber:
+			output = append(output, tok)
+		case tokFunction:
+			stack = append(stack, tok)
+		case tokLeftParen:
+			stack = append(stack, tok)
+			if len(stack) >= 2 && stack[len(stack)-2].kind == tokFunction {
+				argCounts = append(argCounts, 1)
+			}
+		case tokComma:
+			for len(stack) > 0 && stack[len(stack)-1].kind != tokLeftParen {
+				popOperator()
+			}
+			if len(stack) == 0 || len(argCounts) == 0 {
+				return nil, ErrExpressionSyntax
+			}
+			argCounts[len(argCounts)-1]++
+		case tokRightParen:
+			for len(stack) > 0 && stack[len(stack)-1].kind != tokLeftParen {
+				popOperator()
+			}
+			if len(stack) == 0 {
 				return nil, ErrExpressionSyntax
 			}
 			stack = stack[:len(stack)-1] // удалить '('
-		} else if isOperator(token) {
-			for len(stack) > 0 && stack[len(stack)-1] != "(" && precedence(token) <= precedence(stack[len(stack)-1]) {
-				output = append(output, stack[len(stack)-1])
+			if len(stack) > 0 && stack[len(stack)-1].kind == tokFunction {
+				fn := stack[len(stack)-1]
 				stack = stack[:len(stack)-1]
+				argc := argCounts[len(argCounts)-1]
+				argCounts = argCounts[:len(argCounts)-1]
+				fn.argc = argc
+				output = append(output, fn)
+			}
+		case tokOperator:
+			for len(stack) > 0 && stack[len(stack)-1].kind == tokOperator &&
+				(precedence(stack[len(stack)-1].val) > precedence(tok.val) ||
+					(precedence(stack[len(stack)-1].val) == precedence(tok.val) && !rightAssociative[tok.val])) {
+				popOperator()
 			}
-			stack = append(stack, token)
-		} else { // число
-			output = append(output, token)
+			stack = append(stack, tok)
 		}
 	}
 	for len(stack) > 0 {
-		if stack[len(stack)-1] == "(" {
+		if stack[len(stack)-1].kind == tokLeftParen {
 			return nil, ErrExpressionSyntax
 		}
-		output = append(output, stack[len(stack)-1])
-		stack = stack[:len(stack)-1]
+		popOperator()
 	}
 	return output, nil
 }
 
-// Calculate выполняет вычисления над постфиксным выражением
-func calculate(postfix []string) (float64, error) {
+// applyFunction вычисляет значение функции fn над args (уже в порядке следования аргументов)
+func applyFunction(fn string, args []float64) (float64, error) {
+	switch fn {
+	case "sqrt":
+		if args[0] < 0 {
+			return 0, ErrExpressionSyntax
+		}
+		return math.Sqrt(args[0]), nil
+	case "sin":
+		return math.Sin(args[0]), nil
+	case "cos":
+		return math.Cos(args[0]), nil
+	case "abs":
+		return math.Abs(args[0]), nil
+	case "pow":
+		return math.Pow(args[0], args[1]), nil
+	case "min":
+		m := args[0]
+		for _, v := range args[1:] {
+			if v < m {
+				m = v
+			}
+		}
+		return m, nil
+	default:
+		return 0, ErrExpressionSyntax
+	}
+}
+
+// Calculate выполняет вычисления над постфиксным выражением, выдерживая между
+// бинарными операциями искусственные задержки delays (используется воркерами, чтобы
+// вычисление длинных выражений растягивалось по времени)
+func calculate(postfix []token, delays OperationDelays) (float64, error) {
 	stack := make([]float64, 0)
-	for _, token := range postfix {
-		if isOperator(token) {
-			if len(stack) < 2 {
+	for _, tok := range postfix {
+		switch tok.kind {
+		case tokNumber:
+			value, err := strconv.ParseFloat(tok.val, 64)
+			if err != nil {
 				return 0, ErrExpressionSyntax
 			}
-			b := stack[len(stack)-1]
-			a := stack[len(stack)-2]
-			stack = stack[:len(stack)-2]
-			switch token {
-			case "+":
-				stack = append(stack, a+b)
-			case "-":
-				stack = append(stack, a-b)
-			case "*":
-				stack = append(stack, a*b)
-			case "/":
-				if b == 0 {
-					return 0, ErrDivisionByZero
+			stack = append(stack, value)
+		case tokFunction:
+			want := functionArity[tok.val]
+			argc := tok.argc
+			if want >= 0 {
+				if argc != want {
+					return 0, ErrExpressionSyntax
 				}
-				stack = append(stack, a/b)
-			default:
+			} else if argc < -want {
 				return 0, ErrExpressionSyntax
 			}
-		} else {
-			value, err := strconv.ParseFloat(token, 64)
-			if err != nil {
+			if len(stack) < argc {
 				return 0, ErrExpressionSyntax
 			}
-			stack = append(stack, value)
+			args := append([]float64(nil), stack[len(stack)-argc:]...)
+			stack = stack[:len(stack)-argc]
+			result, err := applyFunction(tok.val, args)
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack, result)
+		case tokOperator:
+			switch tok.val {
+			case "u-":
+				if len(stack) < 1 {
+					return 0, ErrExpressionSyntax
+				}
+				stack[len(stack)-1] = -stack[len(stack)-1]
+			case "u+":
+				if len(stack) < 1 {
+					return 0, ErrExpressionSyntax
+				}
+			default:
+				if len(stack) < 2 {
+					return 0, ErrExpressionSyntax
+				}
+				b := stack[len(stack)-1]
+				a := stack[len(stack)-2]
+				stack = stack[:len(stack)-2]
+				switch tok.val {
+				case "+":
+					time.Sleep(delays.Add)
+					stack = append(stack, a+b)
+				case "-":
+					time.Sleep(delays.Sub)
+					stack = append(stack, a-b)
+				case "*":
+					time.Sleep(delays.Mul)
+					stack = append(stack, a*b)
+				case "/":
+					if b == 0 {
+						return 0, ErrDivisionByZero
+					}
+					time.Sleep(delays.Div)
+					stack = append(stack, a/b)
+				case "%":
+					if b == 0 {
+						return 0, ErrDivisionByZero
+					}
+					stack = append(stack, math.Mod(a, b))
+				case "^":
+					stack = append(stack, math.Pow(a, b))
+				default:
+					return 0, ErrExpressionSyntax
+				}
+			}
+		default:
+			return 0, ErrExpressionSyntax
 		}
 	}
 	if len(stack) != 1 {
@@ -131,15 +378,23 @@ func calculate(postfix []string) (float64, error) {
 
 // Calc вычисляет значение выражения
 func Calc(expression string) (float64, error) {
-	if len(expression) < 3 {
+	return CalcWithDelays(expression, OperationDelays{})
+}
+
+// CalcWithDelays вычисляет значение выражения, выдерживая delays между операциями
+func CalcWithDelays(expression string, delays OperationDelays) (float64, error) {
+	if len(strings.TrimSpace(expression)) == 0 {
 		return 0, ErrExpressionSyntax
 	}
-	tokens := strings.Split(strings.ReplaceAll(expression, " ", ""), "")
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return 0, err
+	}
 	postfix, err := convertToPostfix(tokens)
 	if err != nil {
 		return 0, err
 	}
-	result, err := calculate(postfix)
+	result, err := calculate(postfix, delays)
 	if err != nil {
 		return 0, err
 	}
@@ -161,63 +416,20 @@ type BadAnswer struct {
 	Error string `json:"error"`
 }
 
-// Handler для обработки запроса
-func CalculateHandler(w http.ResponseWriter, r *http.Request) {
-	// Проверяем что это Post запрос
-	if r.Method != http.MethodPost {
-		http.Error(w, ErrNotPostMethod.Error(), http.StatusMethodNotAllowed)
-		return
-	}
-	// Считыем тело запроса
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, ErrInternalServerError.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer r.Body.Close()
-
-	// Распарсим тело запроса в структуру
-	var expressionBody Expression
-	if err := json.Unmarshal(body, &expressionBody); err != nil {
-		errorBytes, err := json.Marshal(&BadAnswer{
-			Error: "Invalid request body",
-		})
-		if err != nil {
-			http.Error(w, ErrExpressionSyntax.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, string(errorBytes), http.StatusBadRequest)
-		return
-	}
+func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
-	result, errr := Calc(expressionBody.Data)
+	store := NewMemoryJobStore()
+	pool := NewWorkerPool(store, workerCountFromEnv(), workerCountFromEnv()*4, delaysFromEnv())
+	router := NewRouter(pool)
 
-	if errr != nil {
-		errorBytes, err := json.Marshal(&BadAnswer{
-			Error: errr.Error(),
-		})
-		if err != nil {
-			http.Error(w, ErrExpressionSyntax.Error(), http.StatusBadRequest)
-			return
+	go func() {
+		if err := serveGRPC(grpcAddr); err != nil {
+			fmt.Println("Error starting grpc server:", err)
 		}
-		http.Error(w, string(errorBytes), http.StatusUnprocessableEntity)
-	} else {
-		resultBytes, err := json.Marshal(&GoodAnswer{
-			Result: result,
-		})
-		if err != nil {
-			http.Error(w, ErrExpressionSyntax.Error(), http.StatusOK)
-			return
-		}
-		fmt.Fprint(w, string(resultBytes))
-	}
-}
+	}()
 
-func main() {
-	mux := http.NewServeMux()
-	calculateMux := http.HandlerFunc(CalculateHandler)
-	mux.Handle("/api/v1/calculate", calculateMux)
-	if err := http.ListenAndServe(":8000", mux); err != nil {
+	if err := http.ListenAndServe(":8000", router); err != nil {
 		fmt.Println("Error starting server:", err)
 	}
 }
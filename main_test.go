@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCalcUnaryOperators(t *testing.T) {
+	cases := map[string]float64{
+		"-5":     -5,
+		"+5":     5,
+		"3--2":   5,
+		"3-+2":   1,
+		"-(2+3)": -5,
+		"2*-3":   -6,
+	}
+	for expr, want := range cases {
+		got, err := Calc(expr)
+		if err != nil {
+			t.Fatalf("Calc(%q) returned unexpected error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("Calc(%q) = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestCalcPowAndMod(t *testing.T) {
+	cases := map[string]float64{
+		"2^10":  1024,
+		"2^3^2": 512, // ^ правоассоциативен: 2^(3^2)
+		"10%3":  1,
+		"7.5%2": math.Mod(7.5, 2),
+	}
+	for expr, want := range cases {
+		got, err := Calc(expr)
+		if err != nil {
+			t.Fatalf("Calc(%q) returned unexpected error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("Calc(%q) = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestCalcFunctions(t *testing.T) {
+	cases := map[string]float64{
+		"sqrt(4)":        2,
+		"sqrt(2)":        math.Sqrt(2),
+		"abs(-3)":        3,
+		"pow(2,10)":      1024,
+		"min(3,1,2)":     1,
+		"sin(0)":         0,
+		"cos(0)":         1,
+		"sqrt(pow(2,2))": 2,
+	}
+	for expr, want := range cases {
+		got, err := Calc(expr)
+		if err != nil {
+			t.Fatalf("Calc(%q) returned unexpected error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("Calc(%q) = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+// TestCalcFunctionRequiresCall проверяет регрессию: имя функции без скобок раньше
+// молча съедало следующее число как единственный аргумент (sqrt2 == sqrt(2))
+func TestCalcFunctionRequiresCall(t *testing.T) {
+	exprs := []string{"sqrt2", "sqrt4", "sin1", "sqrt"}
+	for _, expr := range exprs {
+		_, err := Calc(expr)
+		if !errors.Is(err, ErrExpressionSyntax) {
+			t.Errorf("Calc(%q) = _, %v, want ErrExpressionSyntax", expr, err)
+		}
+	}
+}
+
+// TestCalcFunctionWrongArgCount проверяет, что неверное число аргументов у функции
+// с фиксированной арностью даёт ErrExpressionSyntax, а не молча обрезает лишние
+// значения до ожидаемой арности
+func TestCalcFunctionWrongArgCount(t *testing.T) {
+	exprs := []string{"pow(2,3,4)", "pow(2)", "sqrt(1,2)", "sin()"}
+	for _, expr := range exprs {
+		_, err := Calc(expr)
+		if !errors.Is(err, ErrExpressionSyntax) {
+			t.Errorf("Calc(%q) = _, %v, want ErrExpressionSyntax", expr, err)
+		}
+	}
+}
+
+func TestCalcDivisionByZero(t *testing.T) {
+	_, err := Calc("1/0")
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Calc(\"1/0\") = _, %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestCalcInvalidExpression(t *testing.T) {
+	exprs := []string{"", "2+", "(2+3", "2++*3", "foo(1)"}
+	for _, expr := range exprs {
+		_, err := Calc(expr)
+		if err == nil {
+			t.Errorf("Calc(%q) succeeded, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// calcRequestsTotal считает запросы к калькулятору по итоговому HTTP-статусу
+	calcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "calc_requests_total",
+		Help: "Total number of calculator API requests by response status.",
+	}, []string{"status"})
+
+	// calcRequestDuration меряет длительность обработки запроса
+	calcRequestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "calc_request_duration_seconds",
+		Help: "Calculator API request duration in seconds.",
+	})
+
+	// calcExpressionLength меряет длину присланных выражений
+	calcExpressionLength = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "calc_expression_length",
+		Help:    "Length in characters of submitted expressions.",
+		Buckets: prometheus.LinearBuckets(0, 10, 10),
+	})
+)
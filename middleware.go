@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+// responseWriter оборачивает http.ResponseWriter и запоминает код и тело ответа, чтобы
+// middleware могла залогировать и измерить то, что реально ушло клиенту (до этого
+// fmt.Fprint(w, ...) никогда явно не выставлял статус для успешных ответов)
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	rw.status = status
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.body = append(rw.body, b...)
+	return rw.ResponseWriter.Write(b)
+}
+
+// newRequestID генерирует короткий идентификатор запроса для логов
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// requestIDFromContext достаёт request_id, назначенный loggingMiddleware
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// expressionFromBody читает "expression" из тела запроса, не возражая против того, что
+// запрос его не содержит (например GET /api/v1/expressions)
+func expressionFromBody(body []byte) string {
+	var parsed struct {
+		Expression string `json:"expression"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return ""
+	}
+	return parsed.Expression
+}
+
+// errorFromBody читает "error" из JSON-тела ответа об ошибке
+func errorFromBody(body []byte) string {
+	var parsed BadAnswer
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return ""
+	}
+	return parsed.Error
+}
+
+// loggingMiddleware назначает запросу request_id, логирует его через log/slog и
+// записывает метрики Prometheus о количестве, длительности и длине выражений
+func loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := newRequestID()
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey, requestID))
+
+		bodyBytes, err := io.ReadAll(r.Body)
+		if err == nil {
+			r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+		defer r.Body.Close()
+
+		rw := newResponseWriter(w)
+		start := time.Now()
+		next.ServeHTTP(rw, r)
+		duration := time.Since(start)
+
+		expression := expressionFromBody(bodyBytes)
+		errMsg := ""
+		if rw.status >= 400 {
+			errMsg = errorFromBody(rw.body)
+		}
+
+		logFn := slog.Info
+		if rw.status >= 400 {
+			logFn = slog.Error
+		}
+		logFn("request completed",
+			"request_id", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rw.status,
+			"duration_ms", duration.Milliseconds(),
+			"expression", expression,
+			"error", errMsg,
+		)
+
+		calcRequestsTotal.WithLabelValues(strconv.Itoa(rw.status)).Inc()
+		calcRequestDuration.Observe(duration.Seconds())
+		if expression != "" {
+			calcExpressionLength.Observe(float64(len(expression)))
+		}
+	})
+}
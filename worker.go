@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// OperationDelays задаёт искусственную задержку на каждую операцию, управляется
+// переменными окружения ADD_MS, SUB_MS, MUL_MS, DIV_MS
+type OperationDelays struct {
+	Add time.Duration
+	Sub time.Duration
+	Mul time.Duration
+	Div time.Duration
+}
+
+// delaysFromEnv читает задержки операций из переменных окружения (0 по умолчанию)
+func delaysFromEnv() OperationDelays {
+	return OperationDelays{
+		Add: envDurationMS("ADD_MS", 0),
+		Sub: envDurationMS("SUB_MS", 0),
+		Mul: envDurationMS("MUL_MS", 0),
+		Div: envDurationMS("DIV_MS", 0),
+	}
+}
+
+// workerCountFromEnv читает размер пула воркеров из переменной окружения WORKERS
+func workerCountFromEnv() int {
+	return envInt("WORKERS", 4)
+}
+
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+func envDurationMS(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return def
+	}
+	return time.Duration(n) * time.Millisecond
+}
+
+// WorkerPool разбирает задания из буферизованного канала и считает их в фоне
+type WorkerPool struct {
+	store  JobStore
+	jobs   chan *Job
+	delays OperationDelays
+}
+
+// NewWorkerPool запускает workers горутин, читающих задания из канала ёмкости buffer
+func NewWorkerPool(store JobStore, workers int, buffer int, delays OperationDelays) *WorkerPool {
+	wp := &WorkerPool{
+		store:  store,
+		jobs:   make(chan *Job, buffer),
+		delays: delays,
+	}
+	for i := 0; i < workers; i++ {
+		go wp.run()
+	}
+	return wp
+}
+
+// Store возвращает хранилище заданий, с которым работает пул
+func (wp *WorkerPool) Store() JobStore {
+	return wp.store
+}
+
+// Enqueue кладёт задание в очередь на вычисление, не блокируясь: если буфер полон,
+// возвращает false, чтобы вызывающий HTTP-хендлер мог сразу ответить 503, а не зависнуть
+// в ожидании свободного воркера
+func (wp *WorkerPool) Enqueue(job *Job) bool {
+	select {
+	case wp.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+func (wp *WorkerPool) run() {
+	for job := range wp.jobs {
+		wp.process(job)
+	}
+}
+
+func (wp *WorkerPool) process(job *Job) {
+	wp.store.Update(job.ID, func(j *Job) { j.Status = StatusInProgress })
+	result, err := CalcWithDelays(job.Expression, wp.delays)
+	wp.store.Update(job.ID, func(j *Job) {
+		if err != nil {
+			j.Status = StatusError
+			j.Error = err.Error()
+		} else {
+			j.Status = StatusDone
+			j.Result = result
+		}
+	})
+}